@@ -0,0 +1,137 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+)
+
+// The structs below only capture the subset of the ARM template schema for
+// `Microsoft.Insights/scheduledQueryRules` that's needed to confirm a
+// template fragment refers to a `LogToMetricAction` rule - that's as far as
+// `ImportScheduledQueryRuleFromARM` goes. `terraform import` always calls
+// Read immediately after the importer, and Read fetches the rule from Azure
+// and overwrites every field, so there would be no point parsing the rest of
+// the fragment (query, schedule, criteria, etc.) just to have it discarded.
+type armScheduledQueryRuleTemplate struct {
+	Resources []armScheduledQueryRuleResource `json:"resources"`
+}
+
+type armScheduledQueryRuleResource struct {
+	Name       string                          `json:"name"`
+	Type       string                          `json:"type"`
+	Properties armScheduledQueryRuleProperties `json:"properties"`
+}
+
+type armScheduledQueryRuleProperties struct {
+	Action armScheduledQueryRuleAction `json:"action"`
+}
+
+type armScheduledQueryRuleAction struct {
+	OdataType string `json:"odata.type"`
+}
+
+const armActionOdataTypeLogToMetric = "Microsoft.WindowsAzure.Management.Monitoring.Alerts.Models.Microsoft.AppInsights.Nexus.DataContracts.Resources.ScheduledQueryRules.LogToMetricAction"
+
+// ImportScheduledQueryRuleFromARM confirms that an ARM template fragment
+// contains a `Microsoft.Insights/scheduledQueryRules` resource named
+// resourceName using the `LogToMetricAction` variant - the only action type
+// `azurerm_monitor_scheduled_query_rules_log`'s Read can refresh (see its
+// `resp.Action.(type)` switch). This backs the `terraform import
+// azurerm_monitor_scheduled_query_rules_log.foo
+// arm://path/to/template.json#resourceGroup/resourceName` workflow: it lets a
+// mistargeted import (wrong name, or an `AlertingAction` rule that belongs
+// under `azurerm_monitor_scheduled_query_rules_alert[_v2]` instead) fail fast
+// with a useful message instead of a generic "not found" once Azure is
+// queried.
+func ImportScheduledQueryRuleFromARM(raw []byte, resourceName string) error {
+	var template armScheduledQueryRuleTemplate
+	if err := json.Unmarshal(raw, &template); err != nil {
+		return fmt.Errorf("parsing ARM template: %+v", err)
+	}
+
+	resource, err := findArmScheduledQueryRuleResource(template.Resources, resourceName)
+	if err != nil {
+		return err
+	}
+
+	if resource.Properties.Action.OdataType != armActionOdataTypeLogToMetric {
+		return fmt.Errorf("unsupported `action.odata.type` %q - `azurerm_monitor_scheduled_query_rules_log` only supports `LogToMetricAction`; import `AlertingAction` resources as `azurerm_monitor_scheduled_query_rules_alert` or `azurerm_monitor_scheduled_query_rules_alert_v2` instead", resource.Properties.Action.OdataType)
+	}
+
+	return nil
+}
+
+func findArmScheduledQueryRuleResource(resources []armScheduledQueryRuleResource, resourceName string) (*armScheduledQueryRuleResource, error) {
+	for _, resource := range resources {
+		if !strings.EqualFold(resource.Type, "Microsoft.Insights/scheduledQueryRules") {
+			continue
+		}
+		if resourceName == "" || strings.EqualFold(resource.Name, resourceName) {
+			return &resource, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no `Microsoft.Insights/scheduledQueryRules` resource named %q found in the ARM template", resourceName)
+}
+
+// resourceArmMonitorScheduledQueryRulesLogImporter handles both the standard
+// `terraform import azurerm_monitor_scheduled_query_rules_log.foo <resource id>`
+// flow and the `arm://path/to/template.json#resourceGroup/resourceName` flow,
+// which reads a local ARM template fragment to validate the target before
+// setting the real Azure resource ID. `terraform import` always calls Read
+// immediately afterwards, which fetches the rule from Azure and populates
+// every field of state - the ARM fragment itself is never written to state.
+func resourceArmMonitorScheduledQueryRulesLogImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !strings.HasPrefix(d.Id(), "arm://") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	path, resourceGroup, resourceName, err := parseArmImportID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := readArmTemplateFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ImportScheduledQueryRuleFromARM(raw, resourceName); err != nil {
+		return nil, fmt.Errorf("validating %q against ARM template %q: %+v", resourceName, path, err)
+	}
+
+	subscriptionID := meta.(*clients.Client).Account.SubscriptionId
+	d.SetId(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Insights/scheduledqueryrules/%s", subscriptionID, resourceGroup, resourceName))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func readArmTemplateFile(path string) ([]byte, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading ARM template file %q: %+v", path, err)
+	}
+
+	return raw, nil
+}
+
+func parseArmImportID(id string) (path string, resourceGroup string, resourceName string, err error) {
+	trimmed := strings.TrimPrefix(id, "arm://")
+
+	parts := strings.SplitN(trimmed, "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("expected an import ID of the form `arm://path/to/template.json#resourceGroup/resourceName`, got %q", id)
+	}
+
+	refParts := strings.SplitN(parts[1], "/", 2)
+	if len(refParts) != 2 || refParts[0] == "" || refParts[1] == "" {
+		return "", "", "", fmt.Errorf("expected an import ID of the form `arm://path/to/template.json#resourceGroup/resourceName`, got %q", id)
+	}
+
+	return parts[0], refParts[0], refParts[1], nil
+}
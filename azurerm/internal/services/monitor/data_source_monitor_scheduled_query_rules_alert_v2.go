@@ -0,0 +1,244 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmMonitorScheduledQueryRulesAlertV2() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmMonitorScheduledQueryRulesAlertV2Read,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupNameForDataSource(),
+
+			"location": azure.SchemaLocationForDataSource(),
+
+			"scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"severity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"criteria": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"time_aggregation_method": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"threshold": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"operator": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id_column": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"metric_measure_column": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dimension": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"operator": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"values": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"failing_periods": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"number_of_evaluation_periods": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"min_failing_periods_to_alert": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"action": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action_group": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"custom_properties": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"evaluation_frequency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"window_duration": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"mute_actions_after_alert_duration": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"auto_mitigation_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"skip_query_validation": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"target_resource_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"tags": tags.SchemaDataSource(),
+		},
+	}
+}
+
+func dataSourceArmMonitorScheduledQueryRulesAlertV2Read(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.ScheduledQueryRulesV2Client
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return fmt.Errorf("Monitor Scheduled Query Rule Alert V2 %q (Resource Group %q) was not found", name, resourceGroup)
+		}
+		return fmt.Errorf("getting Monitor Scheduled Query Rule Alert V2 %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	if resp.ID == nil || *resp.ID == "" {
+		return fmt.Errorf("API returned a nil/empty id for Monitor Scheduled Query Rule Alert V2 %q (Resource Group %q)", name, resourceGroup)
+	}
+	d.SetId(*resp.ID)
+
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	if props := resp.ScheduledQueryRuleProperties; props != nil {
+		d.Set("description", props.Description)
+		if props.Enabled != nil {
+			d.Set("enabled", *props.Enabled)
+		}
+		if props.AutoMitigate != nil {
+			d.Set("auto_mitigation_enabled", *props.AutoMitigate)
+		}
+		d.Set("skip_query_validation", props.SkipQueryValidation)
+		d.Set("scopes", utils.FlattenStringSlice(props.Scopes))
+		d.Set("evaluation_frequency", props.EvaluationFrequency)
+		d.Set("window_duration", props.WindowSize)
+		d.Set("mute_actions_after_alert_duration", props.MuteActionsDuration)
+		d.Set("target_resource_types", utils.FlattenStringSlice(props.TargetResourceTypes))
+		if props.Severity != nil {
+			d.Set("severity", int(*props.Severity))
+		}
+
+		if err := d.Set("criteria", flattenMonitorScheduledQueryRulesAlertV2Criteria(props.Criteria)); err != nil {
+			return fmt.Errorf("setting `criteria`: %+v", err)
+		}
+
+		if err := d.Set("action", flattenMonitorScheduledQueryRulesAlertV2Action(props.Actions)); err != nil {
+			return fmt.Errorf("setting `action`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
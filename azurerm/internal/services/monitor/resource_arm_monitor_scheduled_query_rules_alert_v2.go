@@ -0,0 +1,585 @@
+package monitor
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/preview/monitor/mgmt/2021-08-01/insights"
+	"github.com/hashicorp/go-azure-helpers/response"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/tf"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/helpers/validate"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/clients"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/features"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/tags"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/timeouts"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+// resourceArmMonitorScheduledQueryRulesAlertV2 exposes the `scheduledqueryrules`
+// 2021-08-01 "v2" schema (criteria-based alerts, auto-mitigation, mute
+// actions). It's registered as its own resource type rather than replacing
+// `azurerm_monitor_scheduled_query_rules_alert`, since the two API versions
+// aren't wire-compatible - existing `_alert` configs keep working unchanged.
+func resourceArmMonitorScheduledQueryRulesAlertV2() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmMonitorScheduledQueryRulesAlertV2CreateUpdate,
+		Read:   resourceArmMonitorScheduledQueryRulesAlertV2Read,
+		Update: resourceArmMonitorScheduledQueryRulesAlertV2CreateUpdate,
+		Delete: resourceArmMonitorScheduledQueryRulesAlertV2Delete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.NoEmptyStrings,
+			},
+
+			"resource_group_name": azure.SchemaResourceGroupName(),
+
+			"location": azure.SchemaLocation(),
+
+			"scopes": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: azure.ValidateResourceID,
+				},
+			},
+
+			"criteria": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"time_aggregation_method": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"Count",
+								"Average",
+								"Minimum",
+								"Maximum",
+								"Total",
+							}, false),
+						},
+						"threshold": {
+							Type:     schema.TypeFloat,
+							Required: true,
+						},
+						"operator": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"Equals",
+								"GreaterThan",
+								"GreaterThanOrEqual",
+								"LessThan",
+								"LessThanOrEqual",
+							}, false),
+						},
+						"resource_id_column": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"metric_measure_column": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
+						"dimension": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validate.NoEmptyStrings,
+									},
+									"operator": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											"Include",
+											"Exclude",
+										}, false),
+									},
+									"values": {
+										Type:     schema.TypeList,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"failing_periods": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"number_of_evaluation_periods": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"min_failing_periods_to_alert": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"action": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action_group": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: azure.ValidateResourceID,
+							},
+						},
+						"custom_properties": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"auto_mitigation_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"evaluation_frequency": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ISO8601Duration,
+			},
+
+			"window_duration": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ISO8601Duration,
+			},
+
+			"mute_actions_after_alert_duration": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ISO8601Duration,
+			},
+
+			"severity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  3,
+				ValidateFunc: validation.IntInSlice([]int{
+					0,
+					1,
+					2,
+					3,
+					4,
+				}),
+			},
+
+			"skip_query_validation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"target_resource_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"tags": tags.Schema(),
+		},
+	}
+}
+
+func resourceArmMonitorScheduledQueryRulesAlertV2CreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.ScheduledQueryRulesV2Client
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	name := d.Get("name").(string)
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	if features.ShouldResourcesBeImported() && d.IsNewResource() {
+		existing, err := client.Get(ctx, resourceGroup, name)
+		if err != nil {
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing Monitor Scheduled Query Rules Alert V2 %q (Resource Group %q): %s", name, resourceGroup, err)
+			}
+		}
+
+		if existing.ID != nil && *existing.ID != "" {
+			return tf.ImportAsExistsError("azurerm_monitor_scheduled_query_rules_alert_v2", *existing.ID)
+		}
+	}
+
+	location := azure.NormalizeLocation(d.Get("location"))
+
+	t := d.Get("tags").(map[string]interface{})
+
+	parameters := insights.ScheduledQueryRuleResource{
+		Location: utils.String(location),
+		ScheduledQueryRuleProperties: &insights.ScheduledQueryRuleProperties{
+			Description:         utils.String(d.Get("description").(string)),
+			Severity:            utils.Int32(int32(d.Get("severity").(int))),
+			Enabled:             utils.Bool(d.Get("enabled").(bool)),
+			Scopes:              utils.ExpandStringSlice(d.Get("scopes").([]interface{})),
+			EvaluationFrequency: utils.String(d.Get("evaluation_frequency").(string)),
+			WindowSize:          utils.String(d.Get("window_duration").(string)),
+			Criteria: &insights.ScheduledQueryRuleCriteria{
+				AllOf: expandMonitorScheduledQueryRulesAlertV2Criteria(d.Get("criteria").([]interface{})),
+			},
+			AutoMitigate:        utils.Bool(d.Get("auto_mitigation_enabled").(bool)),
+			SkipQueryValidation: utils.Bool(d.Get("skip_query_validation").(bool)),
+			TargetResourceTypes: utils.ExpandStringSlice(d.Get("target_resource_types").([]interface{})),
+			Actions:             expandMonitorScheduledQueryRulesAlertV2Action(d.Get("action").(*schema.Set).List()),
+		},
+		Tags: tags.Expand(t),
+	}
+
+	if v, ok := d.GetOk("mute_actions_after_alert_duration"); ok {
+		parameters.ScheduledQueryRuleProperties.MuteActionsDuration = utils.String(v.(string))
+	}
+
+	if _, err := client.CreateOrUpdate(ctx, resourceGroup, name, parameters); err != nil {
+		return fmt.Errorf("creating or updating Monitor Scheduled Query Rule Alert V2 %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	read, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		return err
+	}
+	if read.ID == nil {
+		return fmt.Errorf("Monitor Scheduled Query Rule Alert V2 %q (Resource Group %q) ID is empty", name, resourceGroup)
+	}
+	d.SetId(*read.ID)
+
+	return resourceArmMonitorScheduledQueryRulesAlertV2Read(d, meta)
+}
+
+func resourceArmMonitorScheduledQueryRulesAlertV2Read(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.ScheduledQueryRulesV2Client
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := azure.ParseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["scheduledqueryrules"]
+
+	resp, err := client.Get(ctx, resourceGroup, name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			log.Printf("[DEBUG] Monitor Scheduled Query Rule Alert V2 %q was not found in Resource Group %q - removing from state!", name, resourceGroup)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("getting Monitor Scheduled Query Rule Alert V2 %q (Resource Group %q): %+v", name, resourceGroup, err)
+	}
+
+	d.Set("name", name)
+	d.Set("resource_group_name", resourceGroup)
+	if location := resp.Location; location != nil {
+		d.Set("location", azure.NormalizeLocation(*location))
+	}
+
+	if props := resp.ScheduledQueryRuleProperties; props != nil {
+		d.Set("description", props.Description)
+		if props.Enabled != nil {
+			d.Set("enabled", *props.Enabled)
+		}
+		if props.AutoMitigate != nil {
+			d.Set("auto_mitigation_enabled", *props.AutoMitigate)
+		}
+		d.Set("skip_query_validation", props.SkipQueryValidation)
+		d.Set("scopes", utils.FlattenStringSlice(props.Scopes))
+		d.Set("evaluation_frequency", props.EvaluationFrequency)
+		d.Set("window_duration", props.WindowSize)
+		d.Set("mute_actions_after_alert_duration", props.MuteActionsDuration)
+		d.Set("target_resource_types", utils.FlattenStringSlice(props.TargetResourceTypes))
+		if props.Severity != nil {
+			d.Set("severity", int(*props.Severity))
+		}
+
+		if err := d.Set("criteria", flattenMonitorScheduledQueryRulesAlertV2Criteria(props.Criteria)); err != nil {
+			return fmt.Errorf("setting `criteria`: %+v", err)
+		}
+
+		if err := d.Set("action", flattenMonitorScheduledQueryRulesAlertV2Action(props.Actions)); err != nil {
+			return fmt.Errorf("setting `action`: %+v", err)
+		}
+	}
+
+	return tags.FlattenAndSet(d, resp.Tags)
+}
+
+func resourceArmMonitorScheduledQueryRulesAlertV2Delete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Monitor.ScheduledQueryRulesV2Client
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := azure.ParseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	resourceGroup := id.ResourceGroup
+	name := id.Path["scheduledqueryrules"]
+
+	if resp, err := client.Delete(ctx, resourceGroup, name); err != nil {
+		if !response.WasNotFound(resp.Response) {
+			return fmt.Errorf("deleting Monitor Scheduled Query Rule Alert V2 %q (Resource Group %q): %+v", name, resourceGroup, err)
+		}
+	}
+
+	return nil
+}
+
+func expandMonitorScheduledQueryRulesAlertV2Criteria(input []interface{}) *[]insights.Condition {
+	criteria := make([]insights.Condition, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		timeAggregation := insights.AggregationTypeEnum(v["time_aggregation_method"].(string))
+		operator := insights.ConditionOperator(v["operator"].(string))
+
+		condition := insights.Condition{
+			Query:           utils.String(v["query"].(string)),
+			TimeAggregation: timeAggregation,
+			Threshold:       utils.Float64(v["threshold"].(float64)),
+			Operator:        operator,
+			Dimensions:      expandMonitorScheduledQueryRulesAlertV2Dimensions(v["dimension"].([]interface{})),
+			FailingPeriods:  expandMonitorScheduledQueryRulesAlertV2FailingPeriods(v["failing_periods"].([]interface{})),
+		}
+
+		if resourceIDColumn := v["resource_id_column"].(string); resourceIDColumn != "" {
+			condition.ResourceIDColumn = utils.String(resourceIDColumn)
+		}
+
+		if metricMeasureColumn := v["metric_measure_column"].(string); metricMeasureColumn != "" {
+			condition.MetricMeasureColumn = utils.String(metricMeasureColumn)
+		}
+
+		criteria = append(criteria, condition)
+	}
+
+	return &criteria
+}
+
+func expandMonitorScheduledQueryRulesAlertV2Dimensions(input []interface{}) *[]insights.Dimension {
+	result := make([]insights.Dimension, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		result = append(result, insights.Dimension{
+			Name:     utils.String(v["name"].(string)),
+			Operator: insights.DimensionOperator(v["operator"].(string)),
+			Values:   utils.ExpandStringSlice(v["values"].([]interface{})),
+		})
+	}
+
+	return &result
+}
+
+func expandMonitorScheduledQueryRulesAlertV2FailingPeriods(input []interface{}) *insights.ConditionFailingPeriods {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	v := input[0].(map[string]interface{})
+
+	return &insights.ConditionFailingPeriods{
+		NumberOfEvaluationPeriods: utils.Int64(int64(v["number_of_evaluation_periods"].(int))),
+		MinFailingPeriodsToAlert:  utils.Int64(int64(v["min_failing_periods_to_alert"].(int))),
+	}
+}
+
+func flattenMonitorScheduledQueryRulesAlertV2Criteria(input *insights.ScheduledQueryRuleCriteria) []interface{} {
+	result := make([]interface{}, 0)
+
+	if input == nil || input.AllOf == nil {
+		return result
+	}
+
+	for _, condition := range *input.AllOf {
+		v := make(map[string]interface{})
+
+		if condition.Query != nil {
+			v["query"] = *condition.Query
+		}
+		v["time_aggregation_method"] = string(condition.TimeAggregation)
+		if condition.Threshold != nil {
+			v["threshold"] = *condition.Threshold
+		}
+		v["operator"] = string(condition.Operator)
+		if condition.ResourceIDColumn != nil {
+			v["resource_id_column"] = *condition.ResourceIDColumn
+		}
+		if condition.MetricMeasureColumn != nil {
+			v["metric_measure_column"] = *condition.MetricMeasureColumn
+		}
+
+		v["dimension"] = flattenMonitorScheduledQueryRulesAlertV2Dimensions(condition.Dimensions)
+		v["failing_periods"] = flattenMonitorScheduledQueryRulesAlertV2FailingPeriods(condition.FailingPeriods)
+
+		result = append(result, v)
+	}
+
+	return result
+}
+
+func flattenMonitorScheduledQueryRulesAlertV2Dimensions(input *[]insights.Dimension) []interface{} {
+	result := make([]interface{}, 0)
+
+	if input == nil {
+		return result
+	}
+
+	for _, dimension := range *input {
+		v := make(map[string]interface{})
+
+		if dimension.Name != nil {
+			v["name"] = *dimension.Name
+		}
+		v["operator"] = string(dimension.Operator)
+		if dimension.Values != nil {
+			v["values"] = *dimension.Values
+		}
+
+		result = append(result, v)
+	}
+
+	return result
+}
+
+func flattenMonitorScheduledQueryRulesAlertV2FailingPeriods(input *insights.ConditionFailingPeriods) []interface{} {
+	if input == nil {
+		return make([]interface{}, 0)
+	}
+
+	v := make(map[string]interface{})
+	if input.NumberOfEvaluationPeriods != nil {
+		v["number_of_evaluation_periods"] = int(*input.NumberOfEvaluationPeriods)
+	}
+	if input.MinFailingPeriodsToAlert != nil {
+		v["min_failing_periods_to_alert"] = int(*input.MinFailingPeriodsToAlert)
+	}
+
+	return []interface{}{v}
+}
+
+// expandMonitorScheduledQueryRulesAlertV2Action merges the Action Group IDs and
+// custom properties of every `action` block into the API's single `Actions`
+// object; its flatten counterpart below returns them as one `action` block,
+// and returns no block when the API reports no Action Groups.
+func expandMonitorScheduledQueryRulesAlertV2Action(input []interface{}) *insights.Actions {
+	if len(input) == 0 {
+		return nil
+	}
+
+	actionGroups := make([]string, 0)
+	customProperties := make(map[string]*string)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+
+		for _, ag := range v["action_group"].(*schema.Set).List() {
+			actionGroups = append(actionGroups, ag.(string))
+		}
+
+		for key, val := range v["custom_properties"].(map[string]interface{}) {
+			customProperties[key] = utils.String(val.(string))
+		}
+	}
+
+	return &insights.Actions{
+		ActionGroups:     &actionGroups,
+		CustomProperties: customProperties,
+	}
+}
+
+func flattenMonitorScheduledQueryRulesAlertV2Action(input *insights.Actions) []interface{} {
+	if input == nil || input.ActionGroups == nil || len(*input.ActionGroups) == 0 {
+		return make([]interface{}, 0)
+	}
+
+	customProperties := make(map[string]interface{})
+	for key, val := range input.CustomProperties {
+		if val != nil {
+			customProperties[key] = *val
+		}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"action_group":      utils.FlattenStringSlice(input.ActionGroups),
+			"custom_properties": customProperties,
+		},
+	}
+}
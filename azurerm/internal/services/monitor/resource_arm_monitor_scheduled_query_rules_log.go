@@ -26,7 +26,7 @@ func resourceArmMonitorScheduledQueryRulesLog() *schema.Resource {
 		Update: resourceArmMonitorScheduledQueryRulesLogCreateUpdate,
 		Delete: resourceArmMonitorScheduledQueryRulesLogDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceArmMonitorScheduledQueryRulesLogImporter,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -99,6 +99,7 @@ func resourceArmMonitorScheduledQueryRulesLog() *schema.Resource {
 										Required: true,
 										ValidateFunc: validation.StringInSlice([]string{
 											"Include",
+											"Exclude",
 										}, false),
 									},
 									"values": {
@@ -115,9 +116,22 @@ func resourceArmMonitorScheduledQueryRulesLog() *schema.Resource {
 							Type:     schema.TypeString,
 							Required: true,
 						},
+						"metric_namespace": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validate.NoEmptyStrings,
+						},
 					},
 				},
 			},
+			"action_odata_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  string(insights.OdataTypeMicrosoftWindowsAzureManagementMonitoringAlertsModelsMicrosoftAppInsightsNexusDataContractsResourcesScheduledQueryRulesLogToMetricAction),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(insights.OdataTypeMicrosoftWindowsAzureManagementMonitoringAlertsModelsMicrosoftAppInsightsNexusDataContractsResourcesScheduledQueryRulesLogToMetricAction),
+				}, false),
+			},
 			"data_source_id": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -345,6 +359,7 @@ func resourceArmMonitorScheduledQueryRulesLogRead(d *schema.ResourceData, meta i
 
 	switch action := resp.Action.(type) {
 	case insights.LogToMetricAction:
+		d.Set("action_odata_type", string(action.OdataType))
 		if err = d.Set("criteria", flattenAzureRmScheduledQueryRulesLogCriteria(action.Criteria)); err != nil {
 			return fmt.Errorf("Error setting `criteria`: %+v", err)
 		}
@@ -413,10 +428,16 @@ func expandMonitorScheduledQueryRulesLogCriteria(input []interface{}) *[]insight
 			})
 		}
 
-		criteria = append(criteria, insights.Criteria{
+		criterion := insights.Criteria{
 			MetricName: utils.String(v["metric_name"].(string)),
 			Dimensions: &dimensions,
-		})
+		}
+
+		if metricNamespace := v["metric_namespace"].(string); metricNamespace != "" {
+			criterion.MetricNamespace = utils.String(metricNamespace)
+		}
+
+		criteria = append(criteria, criterion)
 	}
 	return &criteria
 }
@@ -427,7 +448,7 @@ func expandMonitorScheduledQueryRulesLogToMetricAction(d *schema.ResourceData) *
 
 	action := insights.LogToMetricAction{
 		Criteria:  criteria,
-		OdataType: insights.OdataTypeMicrosoftWindowsAzureManagementMonitoringAlertsModelsMicrosoftAppInsightsNexusDataContractsResourcesScheduledQueryRulesLogToMetricAction,
+		OdataType: insights.OdataType(d.Get("action_odata_type").(string)),
 	}
 
 	return &action
@@ -441,7 +462,14 @@ func flattenAzureRmScheduledQueryRulesLogCriteria(input *[]insights.Criteria) []
 			v := make(map[string]interface{})
 
 			v["dimension"] = flattenAzureRmScheduledQueryRulesLogDimension(criteria.Dimensions)
-			v["metric_name"] = *criteria.MetricName
+
+			if criteria.MetricName != nil {
+				v["metric_name"] = *criteria.MetricName
+			}
+
+			if criteria.MetricNamespace != nil {
+				v["metric_namespace"] = *criteria.MetricNamespace
+			}
 
 			result = append(result, v)
 		}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/internal/acceptance"
+)
+
+func TestAccAzureRMMonitorScheduledQueryRulesLog_logToMetricActionDimensionExclude(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_monitor_scheduled_query_rules_log", "test")
+	resourceName := "azurerm_monitor_scheduled_query_rules_log.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { acceptance.PreCheck(t) },
+		Providers: acceptance.SupportedProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzureRMMonitorScheduledQueryRulesLog_logToMetricActionDimensionExcludeConfig(data),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					testCheckAzureRMMonitorScheduledQueryRulesLogDimensionOperator(resourceName, "Exclude"),
+				),
+			},
+		},
+	})
+}
+
+// testCheckAzureRMMonitorScheduledQueryRulesLogDimensionOperator asserts that
+// at least one `criteria.*.dimension.*.operator` attribute has the given
+// value. `criteria` and `dimension` are both TypeSet, so their indices in the
+// flatmap state are content hashes rather than stable positions - this walks
+// the resource's raw attributes instead of guessing at an index.
+func testCheckAzureRMMonitorScheduledQueryRulesLogDimensionOperator(resourceName, operator string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		for key, value := range rs.Primary.Attributes {
+			if strings.Contains(key, ".dimension.") && strings.HasSuffix(key, ".operator") && value == operator {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no `criteria.*.dimension.*.operator` attribute with value %q found on %s", operator, resourceName)
+	}
+}
+
+func testAccAzureRMMonitorScheduledQueryRulesLog_logToMetricActionDimensionExcludeConfig(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_application_insights" "test" {
+  name                = "acctestAppInsights-%d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  application_type    = "web"
+}
+
+resource "azurerm_monitor_scheduled_query_rules_log" "test" {
+  name                = "acctestsqr-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  data_source_id      = azurerm_application_insights.test.id
+
+  criteria {
+    metric_name      = "Average_%% Idle Time"
+    metric_namespace = "Azure.ApplicationInsights"
+
+    dimension {
+      name     = "InstanceName"
+      operator = "Exclude"
+      values   = ["excluded-instance"]
+    }
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}